@@ -0,0 +1,104 @@
+// Package cache stores fetched lyrics on disk so repeat lookups for the
+// same track don't re-hit lyric providers.
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/justinmdickey/golyrics/lyrics"
+)
+
+// NegativeTTL is how long a "not found" result is cached for, which is
+// much shorter than a successful lookup's TTL so a song added to a
+// provider later isn't stuck looking empty for weeks.
+const NegativeTTL = 24 * time.Hour
+
+// Cache reads and writes cached lyric lookups under Dir. A TTL of 0
+// disables caching: Get always misses and Set is a no-op.
+type Cache struct {
+	Dir string
+	TTL time.Duration
+}
+
+// New returns a Cache rooted at dir with the given TTL for positive
+// (found) results. Negative results always use NegativeTTL.
+func New(dir string, ttl time.Duration) *Cache {
+	return &Cache{Dir: dir, TTL: ttl}
+}
+
+type entry struct {
+	Lyrics   lyrics.Lyrics `json:"lyrics"`
+	Found    bool          `json:"found"`
+	CachedAt time.Time     `json:"cachedAt"`
+}
+
+// Get returns the cached result for the given track, if present and not
+// expired. The second return value reports whether the entry represents a
+// successful lookup (true) or a cached "not found" (false); ok reports
+// whether there was a usable cache entry at all.
+func (c *Cache) Get(artist, title, album string, durationMs int) (result lyrics.Lyrics, found bool, ok bool) {
+	if c.TTL == 0 {
+		return lyrics.Lyrics{}, false, false
+	}
+
+	data, err := os.ReadFile(c.path(artist, title, album, durationMs))
+	if err != nil {
+		return lyrics.Lyrics{}, false, false
+	}
+
+	var e entry
+	if err := json.Unmarshal(data, &e); err != nil {
+		return lyrics.Lyrics{}, false, false
+	}
+
+	ttl := c.TTL
+	if !e.Found {
+		ttl = NegativeTTL
+	}
+	if time.Since(e.CachedAt) > ttl {
+		return lyrics.Lyrics{}, false, false
+	}
+
+	return e.Lyrics, e.Found, true
+}
+
+// Set writes result to the cache for the given track. found should be
+// false when recording a negative ("no lyrics") result, which is cached
+// for NegativeTTL regardless of c.TTL.
+func (c *Cache) Set(artist, title, album string, durationMs int, result lyrics.Lyrics, found bool) error {
+	if c.TTL == 0 {
+		return nil
+	}
+
+	if err := os.MkdirAll(c.Dir, 0o755); err != nil {
+		return fmt.Errorf("cache: creating cache dir: %w", err)
+	}
+
+	data, err := json.Marshal(entry{Lyrics: result, Found: found, CachedAt: time.Now()})
+	if err != nil {
+		return fmt.Errorf("cache: encoding entry: %w", err)
+	}
+
+	return os.WriteFile(c.path(artist, title, album, durationMs), data, 0o644)
+}
+
+func (c *Cache) path(artist, title, album string, durationMs int) string {
+	key := fmt.Sprintf("%s|%s|%s|%d", artist, title, album, durationMs)
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.Dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// DefaultDir returns ~/.cache/golyrics, golyrics' default cache location.
+func DefaultDir() string {
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return ".golyrics-cache"
+	}
+	return filepath.Join(base, "golyrics")
+}
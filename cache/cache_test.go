@@ -0,0 +1,100 @@
+package cache
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/justinmdickey/golyrics/lyrics"
+)
+
+func (c *Cache) writeEntry(t *testing.T, artist, title, album string, durationMs int, e entry) {
+	t.Helper()
+	if err := os.MkdirAll(c.Dir, 0o755); err != nil {
+		t.Fatalf("creating cache dir: %v", err)
+	}
+	data, err := json.Marshal(e)
+	if err != nil {
+		t.Fatalf("encoding entry: %v", err)
+	}
+	if err := os.WriteFile(c.path(artist, title, album, durationMs), data, 0o644); err != nil {
+		t.Fatalf("writing entry: %v", err)
+	}
+}
+
+func TestCacheRoundTrip(t *testing.T) {
+	c := New(t.TempDir(), time.Hour)
+	want := lyrics.Lyrics{Plain: "la la la", Source: "test"}
+
+	if err := c.Set("artist", "title", "album", 1000, want, true); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+
+	got, found, ok := c.Get("artist", "title", "album", 1000)
+	if !ok || !found {
+		t.Fatalf("Get: ok=%v found=%v, want true,true", ok, found)
+	}
+	if got != want {
+		t.Errorf("Get lyrics = %+v, want %+v", got, want)
+	}
+}
+
+func TestCacheMiss(t *testing.T) {
+	c := New(t.TempDir(), time.Hour)
+	if _, _, ok := c.Get("artist", "title", "album", 1000); ok {
+		t.Error("Get on empty cache: ok = true, want false")
+	}
+}
+
+func TestCacheDisabled(t *testing.T) {
+	c := New(t.TempDir(), 0)
+	if err := c.Set("artist", "title", "album", 1000, lyrics.Lyrics{Plain: "x"}, true); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if _, _, ok := c.Get("artist", "title", "album", 1000); ok {
+		t.Error("Get with TTL=0: ok = true, want false (cache disabled)")
+	}
+}
+
+func TestCachePositiveResultExpires(t *testing.T) {
+	c := New(t.TempDir(), time.Hour)
+	c.writeEntry(t, "artist", "title", "album", 1000, entry{
+		Lyrics:   lyrics.Lyrics{Plain: "stale"},
+		Found:    true,
+		CachedAt: time.Now().Add(-2 * time.Hour),
+	})
+
+	if _, _, ok := c.Get("artist", "title", "album", 1000); ok {
+		t.Error("Get on expired positive entry: ok = true, want false")
+	}
+}
+
+func TestCacheNegativeResultWithinTTL(t *testing.T) {
+	// c.TTL is much longer than NegativeTTL; a negative entry cached less
+	// than NegativeTTL ago must still be a hit.
+	c := New(t.TempDir(), 30*24*time.Hour)
+	c.writeEntry(t, "artist", "title", "album", 1000, entry{
+		Found:    false,
+		CachedAt: time.Now().Add(-time.Hour),
+	})
+
+	_, found, ok := c.Get("artist", "title", "album", 1000)
+	if !ok || found {
+		t.Fatalf("Get: ok=%v found=%v, want true,false", ok, found)
+	}
+}
+
+func TestCacheNegativeResultExpiresBeforePositiveTTL(t *testing.T) {
+	// A negative entry older than NegativeTTL must expire even though
+	// c.TTL (for positive results) is much longer.
+	c := New(t.TempDir(), 30*24*time.Hour)
+	c.writeEntry(t, "artist", "title", "album", 1000, entry{
+		Found:    false,
+		CachedAt: time.Now().Add(-(NegativeTTL + time.Hour)),
+	})
+
+	if _, _, ok := c.Get("artist", "title", "album", 1000); ok {
+		t.Error("Get on negative entry past NegativeTTL: ok = true, want false")
+	}
+}
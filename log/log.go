@@ -0,0 +1,77 @@
+// Package log wraps log/slog with the small set of levels and the
+// file-only output golyrics needs: when the Bubble Tea TUI is running,
+// nothing may be written to stdout, or it corrupts the alt-screen.
+package log
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+)
+
+// LevelTrace is more verbose than slog's built-in LevelDebug, for the
+// rare message that's noisy even for debugging (e.g. raw HTTP bodies).
+const LevelTrace = slog.Level(-8)
+
+var logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+// Setup points the package logger at file (created/appended to) filtered
+// to level, and returns the open file so the caller can close it on exit.
+// An empty path discards all output; logs never go to stdout/stderr so
+// they can't corrupt an active TUI.
+func Setup(level slog.Level, path string) (*os.File, error) {
+	if path == "" {
+		logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+		return nil, nil
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("log: opening log file: %w", err)
+	}
+
+	logger = slog.New(slog.NewTextHandler(f, &slog.HandlerOptions{Level: level}))
+	return f, nil
+}
+
+// ParseLevel maps a --log-level flag value to a slog.Level. It accepts
+// trace, debug, info, warn, and error (case-insensitive).
+func ParseLevel(s string) (slog.Level, error) {
+	switch strings.ToLower(s) {
+	case "trace":
+		return LevelTrace, nil
+	case "debug":
+		return slog.LevelDebug, nil
+	case "info", "":
+		return slog.LevelInfo, nil
+	case "warn", "warning":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	default:
+		return 0, fmt.Errorf("log: unknown level %q", s)
+	}
+}
+
+func Trace(msg string, args ...any) {
+	logger.Log(context.Background(), LevelTrace, msg, args...)
+}
+
+func Debug(msg string, args ...any) {
+	logger.Debug(msg, args...)
+}
+
+func Info(msg string, args ...any) {
+	logger.Info(msg, args...)
+}
+
+func Warn(msg string, args ...any) {
+	logger.Warn(msg, args...)
+}
+
+func Error(msg string, args ...any) {
+	logger.Error(msg, args...)
+}
@@ -0,0 +1,76 @@
+package lyrics
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Composer fans a lookup out to multiple Providers concurrently and picks
+// the best result: a synchronized (LRC) result beats a plain-only one,
+// regardless of which provider answered first.
+type Composer struct {
+	Providers []Provider
+}
+
+// NewComposer builds a Composer over the given providers, tried in the
+// order given when results tie on sync support.
+func NewComposer(providers ...Provider) *Composer {
+	return &Composer{Providers: providers}
+}
+
+// Search queries every provider concurrently and returns the best result
+// once they have all responded. trackURL is the currently playing
+// track's location, if already known (e.g. from MPRIS metadata); it's
+// passed to providers that implement URLHintProvider instead of having
+// them resolve it themselves. If no provider finds anything, the error
+// from the first provider that isn't a URLHintProvider (i.e. a local
+// lookup, which fails trivially for most tracks) is returned, since
+// that's the one most likely to explain why a real lookup came up
+// empty; if every provider is local, a generic error is returned.
+func (c *Composer) Search(artist, title, album string, durationMs int, trackURL string) (Lyrics, error) {
+	results := make([]Lyrics, len(c.Providers))
+	errs := make([]error, len(c.Providers))
+	isLocal := make([]bool, len(c.Providers))
+
+	var wg sync.WaitGroup
+	for i, p := range c.Providers {
+		wg.Add(1)
+		up, ok := p.(URLHintProvider)
+		isLocal[i] = ok
+		go func(i int, p Provider) {
+			defer wg.Done()
+			if ok {
+				results[i], errs[i] = up.SearchWithURL(artist, title, album, durationMs, trackURL)
+				return
+			}
+			results[i], errs[i] = p.Search(artist, title, album, durationMs)
+		}(i, p)
+	}
+	wg.Wait()
+
+	var best Lyrics
+	var found bool
+	for _, result := range results {
+		if result.IsEmpty() {
+			continue
+		}
+		if !found {
+			best, found = result, true
+			continue
+		}
+		if result.IsSynced() && !best.IsSynced() {
+			best = result
+		}
+	}
+
+	if !found {
+		for i, err := range errs {
+			if err != nil && !isLocal[i] {
+				return Lyrics{}, err
+			}
+		}
+		return Lyrics{}, fmt.Errorf("lyrics: no provider found a result")
+	}
+
+	return best, nil
+}
@@ -0,0 +1,84 @@
+package lyrics
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Export writes l to disk as a plain .txt (if Plain is set) and/or a
+// synced .lrc (if Synced is set), alongside appropriate LRC metadata
+// headers. If trackURL is a local file URL, the sidecar is written next
+// to it so other players picking up that folder see it automatically;
+// otherwise it's written under dir as "Artist - Title". It returns the
+// paths written, in Plain-then-Synced order.
+func Export(l Lyrics, artist, title, album string, durationMs int, dir, trackURL string) ([]string, error) {
+	if l.IsEmpty() {
+		return nil, fmt.Errorf("export: no lyrics to save")
+	}
+
+	base, err := exportBasePath(artist, title, dir, trackURL)
+	if err != nil {
+		return nil, err
+	}
+
+	var written []string
+
+	if l.Plain != "" {
+		path := base + ".txt"
+		if err := os.WriteFile(path, []byte(l.Plain), 0o644); err != nil {
+			return written, fmt.Errorf("export: writing %s: %w", path, err)
+		}
+		written = append(written, path)
+	}
+
+	if l.Synced != "" {
+		path := base + ".lrc"
+		content := withLRCHeaders(l.Synced, artist, title, album, durationMs)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			return written, fmt.Errorf("export: writing %s: %w", path, err)
+		}
+		written = append(written, path)
+	}
+
+	return written, nil
+}
+
+func exportBasePath(artist, title, dir, trackURL string) (string, error) {
+	if trackPath, err := LocalPathFromURL(trackURL); err == nil {
+		return strings.TrimSuffix(trackPath, filepath.Ext(trackPath)), nil
+	}
+
+	if dir == "" {
+		dir = "."
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("export: creating export dir: %w", err)
+	}
+
+	name := strings.ReplaceAll(fmt.Sprintf("%s - %s", artist, title), "/", "-")
+	return filepath.Join(dir, name), nil
+}
+
+func withLRCHeaders(synced, artist, title, album string, durationMs int) string {
+	var b strings.Builder
+	if title != "" {
+		fmt.Fprintf(&b, "[ti:%s]\n", title)
+	}
+	if artist != "" {
+		fmt.Fprintf(&b, "[ar:%s]\n", artist)
+	}
+	if album != "" {
+		fmt.Fprintf(&b, "[al:%s]\n", album)
+	}
+	if durationMs > 0 {
+		totalSec := durationMs / 1000
+		fmt.Fprintf(&b, "[length:%02d:%02d]\n", totalSec/60, totalSec%60)
+	}
+	b.WriteString(synced)
+	if !strings.HasSuffix(synced, "\n") {
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,56 @@
+package lyrics
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FilesystemProvider looks for a sidecar .lrc file next to the audio file
+// currently playing in the media player. It never hits the network.
+type FilesystemProvider struct{}
+
+// NewFilesystemProvider returns a Provider that reads sidecar .lrc files.
+func NewFilesystemProvider() *FilesystemProvider {
+	return &FilesystemProvider{}
+}
+
+func (p *FilesystemProvider) Name() string {
+	return "filesystem"
+}
+
+// Search resolves the track's location itself via playerctl's xesam:url
+// metadata. Callers that already know the track URL (e.g. from MPRIS)
+// should use SearchWithURL instead to avoid the redundant lookup.
+func (p *FilesystemProvider) Search(artist, title, album string, durationMs int) (Lyrics, error) {
+	trackURL, err := PlayerctlTrackURL()
+	if err != nil {
+		return Lyrics{}, fmt.Errorf("filesystem: %w", err)
+	}
+	return p.searchURL(trackURL)
+}
+
+// SearchWithURL looks for a sidecar .lrc using a track URL the caller
+// already resolved, instead of shelling out to playerctl for it again.
+func (p *FilesystemProvider) SearchWithURL(artist, title, album string, durationMs int, trackURL string) (Lyrics, error) {
+	if trackURL == "" {
+		return Lyrics{}, fmt.Errorf("filesystem: no track url")
+	}
+	return p.searchURL(trackURL)
+}
+
+func (p *FilesystemProvider) searchURL(trackURL string) (Lyrics, error) {
+	trackPath, err := LocalPathFromURL(trackURL)
+	if err != nil {
+		return Lyrics{}, fmt.Errorf("filesystem: %w", err)
+	}
+
+	sidecar := strings.TrimSuffix(trackPath, filepath.Ext(trackPath)) + ".lrc"
+	data, err := os.ReadFile(sidecar)
+	if err != nil {
+		return Lyrics{}, fmt.Errorf("filesystem: no sidecar lrc: %w", err)
+	}
+
+	return Lyrics{Synced: string(data), Source: p.Name()}, nil
+}
@@ -0,0 +1,88 @@
+package lyrics
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/justinmdickey/golyrics/log"
+)
+
+// GeniusProvider scrapes lyrics from genius.com. It has no timing data, so
+// it only ever returns plain lyrics.
+type GeniusProvider struct{}
+
+// NewGeniusProvider returns a Provider backed by genius.com search + scrape.
+func NewGeniusProvider() *GeniusProvider {
+	return &GeniusProvider{}
+}
+
+func (p *GeniusProvider) Name() string {
+	return "genius"
+}
+
+func (p *GeniusProvider) Search(artist, title, album string, durationMs int) (Lyrics, error) {
+	song := strings.TrimSpace(artist + " " + title)
+	searchURL := fmt.Sprintf("https://genius.com/search?q=%s", strings.ReplaceAll(song, " ", "%20"))
+
+	resp, err := http.Get(searchURL)
+	if err != nil {
+		log.Error("genius search request failed", "error", err, "song", song)
+		return Lyrics{}, fmt.Errorf("genius: fetching search results: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		log.Warn("genius search returned non-200", "status", resp.StatusCode, "song", song)
+	}
+
+	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	if err != nil {
+		return Lyrics{}, fmt.Errorf("genius: parsing search results: %w", err)
+	}
+
+	var lyricsURL string
+	doc.Find("a[class^='SearchResultSong']").Each(func(i int, s *goquery.Selection) {
+		if i == 0 {
+			lyricsURL, _ = s.Attr("href")
+		}
+	})
+
+	if lyricsURL == "" {
+		log.Debug("genius found no search result, selector may have changed", "song", song)
+		return Lyrics{}, fmt.Errorf("genius: no lyrics found")
+	}
+
+	resp, err = http.Get(lyricsURL)
+	if err != nil {
+		log.Error("genius lyrics page request failed", "error", err, "url", lyricsURL)
+		return Lyrics{}, fmt.Errorf("genius: fetching lyrics page: %w", err)
+	}
+	defer resp.Body.Close()
+
+	doc, err = goquery.NewDocumentFromReader(resp.Body)
+	if err != nil {
+		return Lyrics{}, fmt.Errorf("genius: parsing lyrics page: %w", err)
+	}
+
+	var lyricsText strings.Builder
+	doc.Find("div[class^='Lyrics__Container']").Each(func(i int, s *goquery.Selection) {
+		// Replace <br> with newlines
+		s.Find("br").Each(func(i int, s *goquery.Selection) {
+			s.ReplaceWithHtml("\n")
+		})
+		lyricsText.WriteString(s.Text() + "\n")
+	})
+
+	if lyricsText.Len() == 0 {
+		log.Debug("genius lyrics container not found, selector may have changed", "url", lyricsURL)
+		return Lyrics{}, fmt.Errorf("genius: no lyrics found")
+	}
+
+	// Clean up the lyrics
+	cleanLyrics := strings.ReplaceAll(lyricsText.String(), "[", "\n[")
+	cleanLyrics = strings.ReplaceAll(cleanLyrics, "]", "]\n")
+	cleanLyrics = strings.ReplaceAll(cleanLyrics, "\n\n\n", "\n\n")
+
+	return Lyrics{Plain: cleanLyrics, Source: p.Name()}, nil
+}
@@ -0,0 +1,77 @@
+package lyrics
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// LyricLine is a single timestamped line of synchronized lyrics.
+type LyricLine struct {
+	TimeMs int
+	Text   string
+}
+
+// timeTagRe matches a leading LRC timestamp tag, [mm:ss.xx] or [mm:ss.xxx].
+var timeTagRe = regexp.MustCompile(`^\[(\d+):(\d+(?:\.\d+)?)\]`)
+
+// wordTagRe matches enhanced LRC word-level tags, <mm:ss.xx>, which this
+// parser strips in favor of line-level highlighting.
+var wordTagRe = regexp.MustCompile(`<\d+:\d+(?:\.\d+)?>`)
+
+// ParseLRC parses raw LRC-format text into timestamp-ordered lyric lines.
+// Metadata tags ([ti:], [ar:], [al:], [length:], ...) and blank lines are
+// skipped. A line carrying multiple timestamps, e.g.
+// "[00:12.00][01:20.00]same text", expands into one LyricLine per
+// timestamp. Enhanced word-level tags ("<00:12.34>") are stripped; only
+// line-level timing is kept.
+func ParseLRC(raw string) []LyricLine {
+	var lines []LyricLine
+
+	for _, rawLine := range strings.Split(raw, "\n") {
+		rawLine = strings.TrimSpace(rawLine)
+		if rawLine == "" {
+			continue
+		}
+
+		var times []int
+		rest := rawLine
+		for {
+			m := timeTagRe.FindStringSubmatch(rest)
+			if m == nil {
+				break
+			}
+			times = append(times, parseLRCTimestamp(m[1], m[2]))
+			rest = rest[len(m[0]):]
+		}
+		if len(times) == 0 {
+			// Not a timestamped line (e.g. an [ar:]/[ti:] metadata tag).
+			continue
+		}
+
+		text := wordTagRe.ReplaceAllString(rest, "")
+		for _, t := range times {
+			lines = append(lines, LyricLine{TimeMs: t, Text: text})
+		}
+	}
+
+	sort.Slice(lines, func(i, j int) bool { return lines[i].TimeMs < lines[j].TimeMs })
+	return lines
+}
+
+func parseLRCTimestamp(minutes, seconds string) int {
+	min, _ := strconv.Atoi(minutes)
+	sec, _ := strconv.ParseFloat(seconds, 64)
+	return min*60000 + int(sec*1000)
+}
+
+// ActiveLine returns the index into lines of the line whose TimeMs is the
+// last one at or before positionMs, or -1 if positionMs precedes every
+// line. lines must be sorted ascending by TimeMs, as ParseLRC returns them.
+func ActiveLine(lines []LyricLine, positionMs int) int {
+	idx := sort.Search(len(lines), func(i int) bool {
+		return lines[i].TimeMs > positionMs
+	})
+	return idx - 1
+}
@@ -0,0 +1,72 @@
+package lyrics
+
+import "testing"
+
+func TestParseLRCBasic(t *testing.T) {
+	lines := ParseLRC("[00:12.34]Hello\n[00:15.00]World")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2", len(lines))
+	}
+	if lines[0].TimeMs != 12340 || lines[0].Text != "Hello" {
+		t.Errorf("line 0 = %+v", lines[0])
+	}
+	if lines[1].TimeMs != 15000 || lines[1].Text != "World" {
+		t.Errorf("line 1 = %+v", lines[1])
+	}
+}
+
+func TestParseLRCMultiTimestamp(t *testing.T) {
+	lines := ParseLRC("[00:20.00][00:10.00]Chorus")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2", len(lines))
+	}
+	// Output must be sorted by time, regardless of tag order in the source.
+	if lines[0].TimeMs != 10000 || lines[1].TimeMs != 20000 {
+		t.Errorf("lines not sorted by time: %+v", lines)
+	}
+	if lines[0].Text != "Chorus" || lines[1].Text != "Chorus" {
+		t.Errorf("both expansions should share the line text: %+v", lines)
+	}
+}
+
+func TestParseLRCSkipsMetadataTags(t *testing.T) {
+	lines := ParseLRC("[ar:Some Artist]\n[ti:Some Title]\n[00:01.00]Line")
+	if len(lines) != 1 {
+		t.Fatalf("got %d lines, want 1 (metadata tags should be skipped): %+v", len(lines), lines)
+	}
+	if lines[0].Text != "Line" {
+		t.Errorf("line text = %q, want %q", lines[0].Text, "Line")
+	}
+}
+
+func TestParseLRCStripsWordTags(t *testing.T) {
+	lines := ParseLRC("[00:05.00]<00:05.00>Hel<00:05.20>lo")
+	if len(lines) != 1 {
+		t.Fatalf("got %d lines, want 1", len(lines))
+	}
+	if lines[0].Text != "Hello" {
+		t.Errorf("text = %q, want %q", lines[0].Text, "Hello")
+	}
+}
+
+func TestActiveLine(t *testing.T) {
+	lines := []LyricLine{{TimeMs: 0, Text: "a"}, {TimeMs: 1000, Text: "b"}, {TimeMs: 2000, Text: "c"}}
+
+	cases := []struct {
+		positionMs int
+		want       int
+	}{
+		{-100, -1},
+		{0, 0},
+		{500, 0},
+		{1000, 1},
+		{1999, 1},
+		{2000, 2},
+		{5000, 2},
+	}
+	for _, c := range cases {
+		if got := ActiveLine(lines, c.positionMs); got != c.want {
+			t.Errorf("ActiveLine(lines, %d) = %d, want %d", c.positionMs, got, c.want)
+		}
+	}
+}
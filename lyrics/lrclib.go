@@ -0,0 +1,73 @@
+package lyrics
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/justinmdickey/golyrics/log"
+)
+
+// lrclibBaseURL is the LRCLIB lookup endpoint. Var for test overriding.
+var lrclibBaseURL = "https://lrclib.net/api/get"
+
+type lrclibResponse struct {
+	PlainLyrics  string `json:"plainLyrics"`
+	SyncedLyrics string `json:"syncedLyrics"`
+}
+
+// LRCLIBProvider queries the lrclib.net API, which can return both plain
+// and synchronized (LRC) lyrics in a single lookup.
+type LRCLIBProvider struct{}
+
+// NewLRCLIBProvider returns a Provider backed by the lrclib.net API.
+func NewLRCLIBProvider() *LRCLIBProvider {
+	return &LRCLIBProvider{}
+}
+
+func (p *LRCLIBProvider) Name() string {
+	return "lrclib"
+}
+
+func (p *LRCLIBProvider) Search(artist, title, album string, durationMs int) (Lyrics, error) {
+	q := url.Values{}
+	q.Set("artist_name", artist)
+	q.Set("track_name", title)
+	if album != "" {
+		q.Set("album_name", album)
+	}
+	if durationMs > 0 {
+		q.Set("duration", fmt.Sprintf("%d", durationMs/1000))
+	}
+
+	resp, err := http.Get(lrclibBaseURL + "?" + q.Encode())
+	if err != nil {
+		log.Error("lrclib request failed", "error", err, "artist", artist, "title", title)
+		return Lyrics{}, fmt.Errorf("lrclib: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		log.Warn("lrclib rate limited", "status", resp.StatusCode)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return Lyrics{}, fmt.Errorf("lrclib: no lyrics found (status %d)", resp.StatusCode)
+	}
+
+	var parsed lrclibResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return Lyrics{}, fmt.Errorf("lrclib: decoding response: %w", err)
+	}
+
+	result := Lyrics{
+		Plain:  parsed.PlainLyrics,
+		Synced: parsed.SyncedLyrics,
+		Source: p.Name(),
+	}
+	if result.IsEmpty() {
+		return Lyrics{}, fmt.Errorf("lrclib: no lyrics found")
+	}
+
+	return result, nil
+}
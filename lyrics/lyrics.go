@@ -0,0 +1,45 @@
+// Package lyrics provides a pluggable system for locating song lyrics from
+// multiple sources (web scrapers, lyric APIs, local files) and composing
+// their results into a single best answer.
+package lyrics
+
+// Lyrics holds the result of a successful lookup. A provider may return
+// plain lyrics, synchronized (LRC-format) lyrics, or both.
+type Lyrics struct {
+	// Plain is unsynced lyrics text, one line per song line.
+	Plain string
+	// Synced is raw LRC-formatted text with per-line timestamps, e.g.
+	// "[00:12.34]Some line". Empty if the provider has no timing data.
+	Synced string
+	// Source is the name of the Provider that produced this result.
+	Source string
+}
+
+// IsSynced reports whether Lyrics carries LRC timing data.
+func (l Lyrics) IsSynced() bool {
+	return l.Synced != ""
+}
+
+// IsEmpty reports whether Lyrics has neither plain nor synced text.
+func (l Lyrics) IsEmpty() bool {
+	return l.Plain == "" && l.Synced == ""
+}
+
+// Provider looks up lyrics for a track from a single source.
+type Provider interface {
+	// Name identifies the provider, e.g. "genius", "lrclib", "filesystem".
+	Name() string
+	// Search looks up lyrics for the given track. album and durationMs may
+	// be empty/zero if unknown; providers that don't need them ignore them.
+	Search(artist, title, album string, durationMs int) (Lyrics, error)
+}
+
+// URLHintProvider is implemented by providers that can use a track URL
+// the caller already resolved (e.g. from MPRIS metadata) instead of
+// resolving it themselves, avoiding a redundant lookup.
+type URLHintProvider interface {
+	Provider
+	// SearchWithURL is like Search, but trackURL is the currently playing
+	// track's location (e.g. a file:// URL) if known, or empty if not.
+	SearchWithURL(artist, title, album string, durationMs int, trackURL string) (Lyrics, error)
+}
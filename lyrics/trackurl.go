@@ -0,0 +1,43 @@
+package lyrics
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"os/exec"
+	"strings"
+)
+
+// PlayerctlTrackURL shells out to playerctl for the currently playing
+// track's xesam:url metadata, for use when no MPRIS client is available
+// to read it directly.
+func PlayerctlTrackURL() (string, error) {
+	cmd := exec.Command("playerctl", "metadata", "--format", "{{xesam:url}}")
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("can't get track url: %w", err)
+	}
+
+	raw := strings.TrimSpace(out.String())
+	if raw == "" {
+		return "", fmt.Errorf("no track url")
+	}
+	return raw, nil
+}
+
+// LocalPathFromURL converts a file:// track URL (as reported by
+// xesam:url) into a local filesystem path. It errors if raw is empty or
+// isn't a local file URL.
+func LocalPathFromURL(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "", fmt.Errorf("no track url")
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil || u.Scheme != "file" {
+		return "", fmt.Errorf("track url is not a local file: %s", raw)
+	}
+	return u.Path, nil
+}
@@ -5,52 +5,113 @@ import (
 	"errors"
 	"flag"
 	"fmt"
-	"io"
-	"net/http"
 	"os"
 	"os/exec"
+	"strconv"
 	"strings"
 	"time"
 
-	"github.com/PuerkitoBio/goquery"
 	"github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/justinmdickey/golyrics/cache"
+	"github.com/justinmdickey/golyrics/log"
+	"github.com/justinmdickey/golyrics/lyrics"
+	"github.com/justinmdickey/golyrics/mpris"
 )
 
 var colorFlag string
+var cacheTTLFlag time.Duration
+var exportDirFlag string
+var logLevelFlag string
+var logFileFlag string
+var playerFlag string
 
 func init() {
 	flag.StringVar(&colorFlag, "color", "2", "Set the desired color (name or hex)")
 	flag.StringVar(&colorFlag, "c", "2", "Set the desired color (shorthand)")
+	flag.DurationVar(&cacheTTLFlag, "cache-ttl", 30*24*time.Hour, "How long cached lyrics stay fresh (e.g. 720h); 0 disables the cache")
+	flag.StringVar(&exportDirFlag, "export-dir", ".", "Directory to save exported lyrics to, when the track isn't a local file")
+	flag.StringVar(&logLevelFlag, "log-level", "info", "Log level: trace, debug, info, warn, or error")
+	flag.StringVar(&logFileFlag, "log-file", "", "File to write logs to; logs are discarded if unset (never written to stdout)")
+	flag.StringVar(&playerFlag, "player", "", "MPRIS player to follow, matched as a substring of its bus name suffix (e.g. spotify, mpv); defaults to the first active player")
 }
 
+// toastDuration is how long a transient status message stays on screen.
+const toastDuration = 2 * time.Second
+
 type SongData struct {
-	Status string
-	Title  string
-	Artist string
-	Lyrics string
+	Status     string
+	Title      string
+	Artist     string
+	Album      string
+	DurationMs int
 }
 
 type model struct {
-	songData   SongData
-	color      string
-	width      int
-	height     int
-	lastError  error
-	lastSong   string
+	songData       SongData
+	lyrics         lyrics.Lyrics
+	syncedLines    []lyrics.LyricLine
+	activeLine     int
+	positionMs     int
+	syncOffsetMs   int
+	composer       *lyrics.Composer
+	cache          *cache.Cache
+	mprisClient    *mpris.Client
+	color          string
+	width          int
+	height         int
+	lastError      error
+	lastSong       string
 	fetchingLyrics bool
+	toast          string
+	toastID        int
 }
 
 type tickMsg struct{}
-type lyricsMsg string
+type posTickMsg struct{}
+type mprisChangeMsg struct{}
+
+// lyricsMsg reports the outcome of a fetchLyrics call. Err is set when no
+// provider found anything, so the UI can surface the failure instead of
+// just logging it.
+type lyricsMsg struct {
+	Lyrics lyrics.Lyrics
+	Err    error
+}
+type clearToastMsg struct{ id int }
+
+// visibleSyncedLines is how many lyric lines are shown at once in the
+// karaoke view, centered on the currently active line.
+const visibleSyncedLines = 7
+
+// getSongInfo returns the current track's metadata, preferring a native
+// MPRIS D-Bus query and falling back to shelling out to playerctl if no
+// D-Bus client is available (or it errors).
+func (m *model) getSongInfo() (SongData, error) {
+	if m.mprisClient != nil {
+		md, err := m.mprisClient.Metadata()
+		if err == nil {
+			return SongData{
+				Title:      md.Title,
+				Artist:     md.Artist,
+				Album:      md.Album,
+				Status:     md.Status,
+				DurationMs: md.LengthMs,
+			}, nil
+		}
+		log.Warn("mpris metadata read failed, falling back to playerctl", "error", err)
+	}
+	return getSongInfoPlayerctl()
+}
 
-func getSongInfo() (SongData, error) {
+func getSongInfoPlayerctl() (SongData, error) {
 	var data SongData
 
-	cmd := exec.Command("playerctl", "metadata", "--format", "{{title}}|{{artist}}|{{status}}")
+	cmd := exec.Command("playerctl", "metadata", "--format", "{{title}}|{{artist}}|{{album}}|{{status}}|{{mpris:length}}")
 	var out bytes.Buffer
 	cmd.Stdout = &out
 	if err := cmd.Run(); err != nil {
+		log.Debug("playerctl metadata failed", "error", err)
 		return data, errors.New("can't get metadata")
 	}
 
@@ -60,78 +121,206 @@ func getSongInfo() (SongData, error) {
 	}
 
 	parts := strings.Split(output, "|")
-	if len(parts) != 3 {
+	if len(parts) != 5 {
 		return data, errors.New("unexpected metadata format")
 	}
 
 	data.Title = strings.TrimSpace(parts[0])
 	data.Artist = strings.TrimSpace(parts[1])
-	data.Status = strings.TrimSpace(parts[2])
+	data.Album = strings.TrimSpace(parts[2])
+	data.Status = strings.TrimSpace(parts[3])
+	if lengthUs, err := strconv.Atoi(strings.TrimSpace(parts[4])); err == nil {
+		data.DurationMs = lengthUs / 1000
+	}
 
 	return data, nil
 }
 
-func fetchLyrics(song string) tea.Msg {
-	searchURL := fmt.Sprintf("https://genius.com/search?q=%s", strings.ReplaceAll(song, " ", "%20"))
+// getPlaybackPosition returns the current playback position and track
+// duration in milliseconds, preferring a native MPRIS D-Bus query and
+// falling back to playerctl.
+func (m *model) getPlaybackPosition() (positionMs int, durationMs int, err error) {
+	if m.mprisClient != nil {
+		posMs, readErr := m.mprisClient.Position()
+		if readErr == nil {
+			var md mpris.Metadata
+			md, readErr = m.mprisClient.Metadata()
+			if readErr == nil {
+				return posMs, md.LengthMs, nil
+			}
+		}
+		log.Warn("mpris position read failed, falling back to playerctl", "error", readErr)
+	}
+	return getPlaybackPositionPlayerctl()
+}
+
+func getPlaybackPositionPlayerctl() (positionMs int, durationMs int, err error) {
+	cmd := exec.Command("playerctl", "metadata", "--format", "{{position}}/{{mpris:length}}")
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	if err := cmd.Run(); err != nil {
+		return 0, 0, errors.New("can't get playback position")
+	}
+
+	parts := strings.Split(strings.TrimSpace(out.String()), "/")
+	if len(parts) != 2 {
+		return 0, 0, errors.New("unexpected position format")
+	}
 
-	resp, err := http.Get(searchURL)
+	positionUs, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, errors.New("unexpected position format")
+	}
+	durationUs, err := strconv.Atoi(parts[1])
 	if err != nil {
-		return lyricsMsg("Error fetching lyrics")
+		return 0, 0, errors.New("unexpected position format")
 	}
-	defer resp.Body.Close()
 
-	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	return positionUs / 1000, durationUs / 1000, nil
+}
+
+func defaultComposer() *lyrics.Composer {
+	return lyrics.NewComposer(
+		lyrics.NewFilesystemProvider(),
+		lyrics.NewLRCLIBProvider(),
+		lyrics.NewGeniusProvider(),
+	)
+}
+
+// fetchLyrics searches providers for a song without consulting the cache,
+// storing whatever is found (or the negative result) for next time.
+func fetchLyrics(composer *lyrics.Composer, c *cache.Cache, artist, title, album string, durationMs int, trackURL string) tea.Msg {
+	result, err := composer.Search(artist, title, album, durationMs, trackURL)
 	if err != nil {
-		return lyricsMsg("Error parsing search results")
+		log.Warn("no lyrics found for song", "artist", artist, "title", title, "error", err)
+		c.Set(artist, title, album, durationMs, lyrics.Lyrics{}, false)
+		return lyricsMsg{Lyrics: lyrics.Lyrics{Plain: "No lyrics found"}, Err: err}
 	}
+	c.Set(artist, title, album, durationMs, result, true)
+	return lyricsMsg{Lyrics: result}
+}
 
-	var lyricsURL string
-	doc.Find("a[class^='SearchResultSong']").Each(func(i int, s *goquery.Selection) {
-		if i == 0 {
-			lyricsURL, _ = s.Attr("href")
+// resolveTrackURL returns the currently playing track's location (e.g. a
+// file:// URL), preferring a native MPRIS D-Bus query and falling back
+// to shelling out to playerctl if no D-Bus client is available (or it
+// errors), same as getSongInfo, getPlaybackPosition, and controlPlayer.
+func (m *model) resolveTrackURL() string {
+	if m.mprisClient != nil {
+		md, err := m.mprisClient.Metadata()
+		if err == nil {
+			return md.URL
 		}
-	})
-
-	if lyricsURL == "" {
-		return lyricsMsg("No lyrics found")
+		log.Warn("mpris metadata read failed, falling back to playerctl", "error", err)
 	}
 
-	resp, err = http.Get(lyricsURL)
+	trackURL, err := lyrics.PlayerctlTrackURL()
 	if err != nil {
-		return lyricsMsg("Error fetching lyrics page")
+		return ""
+	}
+	return trackURL
+}
+
+// applyLyrics installs a fetched (or cached) lyrics result into the model,
+// re-deriving the synced-line state that depends on it.
+func (m *model) applyLyrics(l lyrics.Lyrics) {
+	m.lyrics = l
+	m.syncOffsetMs = 0
+	if l.IsSynced() {
+		m.syncedLines = lyrics.ParseLRC(l.Synced)
+	} else {
+		m.syncedLines = nil
 	}
-	defer resp.Body.Close()
+	m.activeLine = lyrics.ActiveLine(m.syncedLines, m.positionMs)
+}
 
-	doc, err = goquery.NewDocumentFromReader(resp.Body)
+// exportLyrics saves the currently displayed lyrics to disk and arms a
+// toast command reporting the outcome.
+func (m *model) exportLyrics() tea.Cmd {
+	paths, err := lyrics.Export(m.lyrics, m.songData.Artist, m.songData.Title, m.songData.Album, m.songData.DurationMs, exportDirFlag, m.resolveTrackURL())
 	if err != nil {
-		return lyricsMsg("Error parsing lyrics page")
+		return m.showToast("Export failed: " + err.Error())
 	}
+	return m.showToast("Saved to " + strings.Join(paths, ", "))
+}
 
-	var lyrics strings.Builder
-	doc.Find("div[class^='Lyrics__Container']").Each(func(i int, s *goquery.Selection) {
-		// Replace <br> with newlines
-		s.Find("br").Each(func(i int, s *goquery.Selection) {
-			s.ReplaceWithHtml("\n")
-		})
-		lyrics.WriteString(s.Text() + "\n")
+// showToast displays msg and returns a command that clears it after
+// toastDuration, unless a newer toast has replaced it by then.
+func (m *model) showToast(msg string) tea.Cmd {
+	m.toast = msg
+	m.toastID++
+	id := m.toastID
+	return tea.Tick(toastDuration, func(time.Time) tea.Msg {
+		return clearToastMsg{id: id}
 	})
+}
 
-	if lyrics.Len() == 0 {
-		return lyricsMsg("No lyrics found")
+// refreshSong re-polls the current track and, if it changed, updates the
+// model and either serves lyrics from cache or kicks off a fetch. Shared
+// by the once-a-second fallback poll and the instant MPRIS signal path.
+func (m *model) refreshSong() tea.Cmd {
+	data, err := m.getSongInfo()
+	if err != nil {
+		m.lastError = err
+		return nil
 	}
 
-	// Clean up the lyrics
-	cleanLyrics := strings.ReplaceAll(lyrics.String(), "[", "\n[")
-	cleanLyrics = strings.ReplaceAll(cleanLyrics, "]", "]\n")
-	cleanLyrics = strings.ReplaceAll(cleanLyrics, "\n\n\n", "\n\n")
+	currentSong := data.Artist + " " + data.Title
+	if currentSong != m.lastSong && !m.fetchingLyrics {
+		m.lastSong = currentSong
+		m.songData = data
 
-	return lyricsMsg(cleanLyrics)
+		if cached, found, ok := m.cache.Get(data.Artist, data.Title, data.Album, data.DurationMs); ok {
+			if found {
+				m.applyLyrics(cached)
+			} else {
+				m.applyLyrics(lyrics.Lyrics{Plain: "No lyrics found"})
+			}
+		} else {
+			m.fetchingLyrics = true
+			trackURL := m.resolveTrackURL()
+			return func() tea.Msg {
+				return fetchLyrics(m.composer, m.cache, data.Artist, data.Title, data.Album, data.DurationMs, trackURL)
+			}
+		}
+	}
+	m.songData.Status = data.Status
+	m.lastError = nil
+	return nil
 }
 
 func (m model) Init() tea.Cmd {
-	return tea.Tick(time.Second, func(time.Time) tea.Msg {
-		return tickMsg{}
-	})
+	cmds := []tea.Cmd{
+		tea.Tick(time.Second, func(time.Time) tea.Msg {
+			return tickMsg{}
+		}),
+		tea.Tick(200*time.Millisecond, func(time.Time) tea.Msg {
+			return posTickMsg{}
+		}),
+	}
+	if m.mprisClient != nil {
+		cmds = append(cmds, listenMPRIS(m.mprisClient))
+	}
+	return tea.Batch(cmds...)
+}
+
+// reconnectMPRIS retries mpris.NewClient when no MPRIS client is
+// currently connected, e.g. because no player was registered yet at
+// startup. On success it arms the signal listener so the model stops
+// relying on the once-a-second playerctl fallback.
+func (m *model) reconnectMPRIS() tea.Cmd {
+	if m.mprisClient != nil {
+		return nil
+	}
+
+	client, err := mpris.NewClient(playerFlag)
+	if err != nil {
+		log.Debug("mpris still unavailable", "error", err)
+		return nil
+	}
+
+	log.Info("mpris connected", "player", playerFlag)
+	m.mprisClient = client
+	return listenMPRIS(client)
 }
 
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -141,52 +330,70 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "q":
 			return m, tea.Quit
 		case "p":
-			controlPlayer("play-pause")
+			m.controlPlayer("play-pause")
 		case "n":
-			controlPlayer("next")
+			m.controlPlayer("next")
 		case "b":
-			controlPlayer("previous")
+			m.controlPlayer("previous")
 		case "r":
 			if m.songData.Title != "" && m.songData.Artist != "" {
 				m.fetchingLyrics = true
+				artist, title := m.songData.Artist, m.songData.Title
+				album, durationMs := m.songData.Album, m.songData.DurationMs
+				trackURL := m.resolveTrackURL()
 				return m, tea.Batch(
 					func() tea.Msg {
-						return fetchLyrics(m.songData.Artist + " " + m.songData.Title)
+						return fetchLyrics(m.composer, m.cache, artist, title, album, durationMs, trackURL)
 					},
 				)
 			}
+		case "[":
+			m.syncOffsetMs -= 100
+		case "]":
+			m.syncOffsetMs += 100
+		case "e":
+			if !m.lyrics.IsEmpty() {
+				cmd := m.exportLyrics()
+				return m, cmd
+			}
 		}
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
 	case lyricsMsg:
 		m.fetchingLyrics = false
-		m.songData.Lyrics = string(msg)
-	case tickMsg:
-		data, err := getSongInfo()
-		if err != nil {
-			m.lastError = err
-		} else {
-			currentSong := data.Artist + " " + data.Title
-			if currentSong != m.lastSong && !m.fetchingLyrics {
-				m.lastSong = currentSong
-				m.fetchingLyrics = true
-				m.songData = data
-				return m, tea.Batch(
-					tea.Tick(time.Second, func(time.Time) tea.Msg {
-						return tickMsg{}
-					}),
-					func() tea.Msg {
-						return fetchLyrics(currentSong)
-					},
-				)
+		m.applyLyrics(msg.Lyrics)
+		if msg.Err != nil {
+			return m, m.showToast("Lyrics lookup failed: " + msg.Err.Error())
+		}
+	case posTickMsg:
+		posMs, _, err := m.getPlaybackPosition()
+		if err == nil {
+			m.positionMs = posMs
+			if len(m.syncedLines) > 0 {
+				m.activeLine = lyrics.ActiveLine(m.syncedLines, m.positionMs+m.syncOffsetMs)
 			}
-			m.songData.Status = data.Status
-			m.lastError = nil
 		}
-		return m, tea.Tick(time.Second, func(time.Time) tea.Msg {
-			return tickMsg{}
+		return m, tea.Tick(200*time.Millisecond, func(time.Time) tea.Msg {
+			return posTickMsg{}
 		})
+	case clearToastMsg:
+		if msg.id == m.toastID {
+			m.toast = ""
+		}
+	case mprisChangeMsg:
+		fetchCmd := m.refreshSong()
+		return m, tea.Batch(listenMPRIS(m.mprisClient), fetchCmd)
+	case tickMsg:
+		connectCmd := m.reconnectMPRIS()
+		fetchCmd := m.refreshSong()
+		return m, tea.Batch(
+			tea.Tick(time.Second, func(time.Time) tea.Msg {
+				return tickMsg{}
+			}),
+			connectCmd,
+			fetchCmd,
+		)
 	}
 	return m, nil
 }
@@ -229,8 +436,10 @@ func (m model) View() string {
 
 		if m.fetchingLyrics {
 			content.WriteString("\nFetching lyrics...")
-		} else if m.songData.Lyrics != "" {
-			content.WriteString("\nLyrics:\n" + m.songData.Lyrics)
+		} else if len(m.syncedLines) > 0 {
+			content.WriteString("\n" + m.renderSyncedLyrics(color))
+		} else if m.lyrics.Plain != "" {
+			content.WriteString("\nLyrics:\n" + m.lyrics.Plain)
 		}
 	}
 
@@ -244,10 +453,16 @@ func (m model) View() string {
 		"  Next: "+highlight.Render("n"),
 		"  Previous: "+highlight.Render("b"),
 		"  Refresh Lyrics: "+highlight.Render("r"),
+		"  Sync: "+highlight.Render("[")+"/"+highlight.Render("]"),
+		"  Export: "+highlight.Render("e"),
 		"  Quit: "+highlight.Render("q"),
 	)
 
 	fullUI := lipgloss.JoinVertical(lipgloss.Center, contentStr, "\n"+helpText)
+	if m.toast != "" {
+		toastStyle := lipgloss.NewStyle().Foreground(color).Italic(true)
+		fullUI = lipgloss.JoinVertical(lipgloss.Center, fullUI, "\n"+toastStyle.Render(m.toast))
+	}
 
 	return lipgloss.Place(
 		m.width, m.height,
@@ -256,15 +471,115 @@ func (m model) View() string {
 	)
 }
 
-func controlPlayer(command string) error {
+// renderSyncedLyrics renders a window of LRC lines centered on the active
+// line: the active line is bold and colored, past lines are dimmed, and
+// future lines are gray.
+func (m model) renderSyncedLyrics(color lipgloss.Color) string {
+	activeStyle := lipgloss.NewStyle().Bold(true).Foreground(color)
+	pastStyle := lipgloss.NewStyle().Faint(true)
+	futureStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+
+	lines := m.syncedLines
+	active := m.activeLine
+
+	half := visibleSyncedLines / 2
+	start := active - half
+	if start < 0 {
+		start = 0
+	}
+	end := start + visibleSyncedLines
+	if end > len(lines) {
+		end = len(lines)
+		start = end - visibleSyncedLines
+		if start < 0 {
+			start = 0
+		}
+	}
+
+	var out strings.Builder
+	for i := start; i < end; i++ {
+		text := lines[i].Text
+		if text == "" {
+			text = " "
+		}
+		switch {
+		case i == active:
+			out.WriteString(activeStyle.Render(text))
+		case i < active:
+			out.WriteString(pastStyle.Render(text))
+		default:
+			out.WriteString(futureStyle.Render(text))
+		}
+		out.WriteString("\n")
+	}
+
+	return out.String()
+}
+
+// controlPlayer sends a transport command, preferring the native MPRIS
+// D-Bus client and falling back to playerctl.
+func (m *model) controlPlayer(command string) error {
+	if m.mprisClient != nil {
+		var err error
+		switch command {
+		case "play-pause":
+			err = m.mprisClient.PlayPause()
+		case "next":
+			err = m.mprisClient.Next()
+		case "previous":
+			err = m.mprisClient.Previous()
+		}
+		if err == nil {
+			return nil
+		}
+		log.Warn("mpris control command failed, falling back to playerctl", "command", command, "error", err)
+	}
 	return exec.Command("playerctl", command).Run()
 }
 
+// listenMPRIS blocks until the player's next PropertiesChanged signal,
+// then returns a message prompting an immediate song-info refresh. This
+// is what lets the model react to track changes instantly instead of
+// waiting for the once-a-second poll.
+func listenMPRIS(client *mpris.Client) tea.Cmd {
+	return func() tea.Msg {
+		if _, ok := <-client.Changes(); !ok {
+			return nil
+		}
+		return mprisChangeMsg{}
+	}
+}
+
 func main() {
 	flag.Parse()
 
+	level, err := log.ParseLevel(logLevelFlag)
+	if err != nil {
+		fmt.Printf("Error: %v", err)
+		os.Exit(1)
+	}
+	logFile, err := log.Setup(level, logFileFlag)
+	if err != nil {
+		fmt.Printf("Error: %v", err)
+		os.Exit(1)
+	}
+	if logFile != nil {
+		defer logFile.Close()
+	}
+
+	mprisClient, err := mpris.NewClient(playerFlag)
+	if err != nil {
+		log.Info("mpris unavailable, falling back to playerctl", "error", err)
+		mprisClient = nil
+	} else {
+		defer mprisClient.Close()
+	}
+
 	initialModel := model{
-		color: colorFlag,
+		color:       colorFlag,
+		composer:    defaultComposer(),
+		cache:       cache.New(cache.DefaultDir(), cacheTTLFlag),
+		mprisClient: mprisClient,
 	}
 
 	if _, err := tea.NewProgram(initialModel, tea.WithAltScreen()).Run(); err != nil {
@@ -0,0 +1,179 @@
+// Package mpris is a minimal MPRIS2 (Media Player Remote Interfacing
+// Specification) client over D-Bus, used in place of shelling out to
+// playerctl for every poll. It lets golyrics react to PropertiesChanged
+// signals the instant a track changes, seeks, or pauses.
+package mpris
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/godbus/dbus/v5"
+)
+
+const (
+	busNamePrefix = "org.mpris.MediaPlayer2."
+	objectPath    = "/org/mpris/MediaPlayer2"
+	playerIface   = "org.mpris.MediaPlayer2.Player"
+)
+
+// Metadata is the subset of MPRIS player state golyrics needs.
+type Metadata struct {
+	Title    string
+	Artist   string
+	Album    string
+	Status   string
+	URL      string
+	LengthMs int
+}
+
+// Client talks to a single MPRIS player over an existing D-Bus session
+// bus connection.
+type Client struct {
+	conn    *dbus.Conn
+	busName string
+	signals chan *dbus.Signal
+}
+
+// Players lists the active MPRIS players' bus name suffixes (e.g.
+// "spotify", "mpv.instance1"), as matched against the --player flag.
+func Players() ([]string, error) {
+	conn, err := dbus.SessionBus()
+	if err != nil {
+		return nil, fmt.Errorf("mpris: connecting to session bus: %w", err)
+	}
+
+	var names []string
+	if err := conn.BusObject().Call("org.freedesktop.DBus.ListNames", 0).Store(&names); err != nil {
+		return nil, fmt.Errorf("mpris: listing bus names: %w", err)
+	}
+
+	var players []string
+	for _, n := range names {
+		if strings.HasPrefix(n, busNamePrefix) {
+			players = append(players, strings.TrimPrefix(n, busNamePrefix))
+		}
+	}
+	return players, nil
+}
+
+// NewClient connects to the session bus and binds to the first active
+// MPRIS player whose bus name suffix contains filter (e.g. "spotify",
+// "mpv", "firefox"). An empty filter matches the first active player.
+func NewClient(filter string) (*Client, error) {
+	conn, err := dbus.SessionBus()
+	if err != nil {
+		return nil, fmt.Errorf("mpris: connecting to session bus: %w", err)
+	}
+
+	players, err := Players()
+	if err != nil {
+		return nil, err
+	}
+	if len(players) == 0 {
+		return nil, fmt.Errorf("mpris: no active players")
+	}
+
+	var match string
+	for _, p := range players {
+		if filter == "" || strings.Contains(p, filter) {
+			match = p
+			break
+		}
+	}
+	if match == "" {
+		return nil, fmt.Errorf("mpris: no player matching %q (active: %s)", filter, strings.Join(players, ", "))
+	}
+
+	c := &Client{conn: conn, busName: busNamePrefix + match}
+
+	if err := conn.AddMatchSignal(
+		dbus.WithMatchObjectPath(objectPath),
+		dbus.WithMatchInterface("org.freedesktop.DBus.Properties"),
+		dbus.WithMatchSender(c.busName),
+	); err != nil {
+		return nil, fmt.Errorf("mpris: subscribing to signals: %w", err)
+	}
+
+	c.signals = make(chan *dbus.Signal, 16)
+	conn.Signal(c.signals)
+
+	return c, nil
+}
+
+// Close releases the underlying D-Bus connection.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// Changes is the channel PropertiesChanged notifications arrive on.
+// Receivers should re-fetch with Metadata rather than parse the signal
+// body, since MPRIS senders may omit properties that didn't change.
+func (c *Client) Changes() <-chan *dbus.Signal {
+	return c.signals
+}
+
+func (c *Client) object() dbus.BusObject {
+	return c.conn.Object(c.busName, dbus.ObjectPath(objectPath))
+}
+
+func (c *Client) property(name string) (dbus.Variant, error) {
+	return c.object().GetProperty(playerIface + "." + name)
+}
+
+// Metadata fetches the player's current track metadata and playback
+// status.
+func (c *Client) Metadata() (Metadata, error) {
+	metaVariant, err := c.property("Metadata")
+	if err != nil {
+		return Metadata{}, fmt.Errorf("mpris: reading metadata: %w", err)
+	}
+	statusVariant, err := c.property("PlaybackStatus")
+	if err != nil {
+		return Metadata{}, fmt.Errorf("mpris: reading playback status: %w", err)
+	}
+
+	fields, ok := metaVariant.Value().(map[string]dbus.Variant)
+	if !ok {
+		return Metadata{}, fmt.Errorf("mpris: unexpected metadata shape")
+	}
+
+	var md Metadata
+	md.Status, _ = statusVariant.Value().(string)
+	md.Title, _ = fields["xesam:title"].Value().(string)
+	md.Album, _ = fields["xesam:album"].Value().(string)
+	md.URL, _ = fields["xesam:url"].Value().(string)
+	if artists, ok := fields["xesam:artist"].Value().([]string); ok && len(artists) > 0 {
+		md.Artist = strings.Join(artists, ", ")
+	}
+	if lengthUs, ok := fields["mpris:length"].Value().(int64); ok {
+		md.LengthMs = int(lengthUs / 1000)
+	}
+
+	return md, nil
+}
+
+// Position returns the current playback position in milliseconds.
+func (c *Client) Position() (int, error) {
+	v, err := c.property("Position")
+	if err != nil {
+		return 0, fmt.Errorf("mpris: reading position: %w", err)
+	}
+	posUs, ok := v.Value().(int64)
+	if !ok {
+		return 0, fmt.Errorf("mpris: unexpected position shape")
+	}
+	return int(posUs / 1000), nil
+}
+
+func (c *Client) PlayPause() error {
+	return c.object().Call(playerIface+".PlayPause", 0).Err
+}
+
+func (c *Client) Next() error {
+	return c.object().Call(playerIface+".Next", 0).Err
+}
+
+func (c *Client) Previous() error {
+	return c.object().Call(playerIface+".Previous", 0).Err
+}